@@ -0,0 +1,62 @@
+// Package auth issues and validates the JWTs used to authenticate API
+// requests, and hashes the passwords backing them.
+package auth
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrInvalidToken is returned when a bearer token fails validation or is
+// missing the claims AuthRequired needs.
+var ErrInvalidToken = errors.New("auth: invalid token")
+
+// TokenIssuer signs and verifies the JWTs handed out at login.
+type TokenIssuer struct {
+	secret	[]byte
+	ttl			time.Duration
+}
+
+// NewTokenIssuer builds a TokenIssuer with the given signing secret and
+// token lifetime.
+func NewTokenIssuer(secret []byte, ttl time.Duration) *TokenIssuer {
+	return &TokenIssuer{secret: secret, ttl: ttl}
+}
+
+// Issue returns a signed JWT carrying userID as the "user_id" claim.
+func (i *TokenIssuer) Issue(userID string) (string, error) {
+	claims := jwt.MapClaims{
+		"user_id": userID,
+		"exp": 		time.Now().Add(i.ttl).Unix(),
+		"iat": 		time.Now().Unix(),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(i.secret)
+}
+
+// UserID validates tokenString and extracts its "user_id" claim.
+func (i *TokenIssuer) UserID(tokenString string) (string, error) {
+	token, err := jwt.Parse(tokenString, func(t *jwt.Token) (interface{}, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return i.secret, nil
+	})
+	if err != nil || !token.Valid {
+		return "", ErrInvalidToken
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	userID, ok := claims["user_id"].(string)
+	if !ok || userID == "" {
+		return "", ErrInvalidToken
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,69 @@
+// Package config loads server configuration from environment variables,
+// falling back to developer-friendly defaults so the server still runs
+// untouched outside of docker/k8s.
+package config
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"time"
+)
+
+// Config holds every value the server needs at startup.
+type Config struct {
+	MongoHost					string	// host:port mongod is listening on
+	MongoUser					string	// optional; enables authenticated dial string
+	MongoPassword			string
+	DBName						string
+	CollectionName		string
+	UsersCollection		string
+	Port							string
+	JWTSecret					string
+	JWTTTL						time.Duration
+}
+
+// Load builds a Config from the environment, falling back to defaults for
+// anything unset.
+func Load() Config {
+	return Config{
+		MongoHost: 				getEnv("MONGO_HOST", "localhost:27017"),
+		MongoUser: 				os.Getenv("MONGO_USER"),
+		MongoPassword: 		os.Getenv("MONGO_PASSWORD"),
+		DBName: 					getEnv("MONGO_DB", "demo_todo"),
+		CollectionName: 	getEnv("MONGO_COLLECTION", "todo"),
+		UsersCollection: 	getEnv("MONGO_USERS_COLLECTION", "users"),
+		Port: 						getEnv("PORT", ":9000"),
+		JWTSecret: 				getEnv("JWT_SECRET", "change-me-in-production"),
+		JWTTTL: 					getEnvDuration("JWT_TTL", 24*time.Hour),
+	}
+}
+
+// MongoURI builds the mongodb:// dial string, embedding MongoUser/
+// MongoPassword credentials when present.
+func (c Config) MongoURI() string {
+	if c.MongoUser == "" {
+		return fmt.Sprintf("mongodb://%s", c.MongoHost)
+	}
+	return fmt.Sprintf("mongodb://%s:%s@%s", c.MongoUser, c.MongoPassword, c.MongoHost)
+}
+
+func getEnv(key, fallback string) string {
+	if v := os.Getenv(key); v != "" {
+		return v
+	}
+	return fallback
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Printf("config: invalid %s=%q, using default %s", key, v, fallback)
+		return fallback
+	}
+	return d
+}
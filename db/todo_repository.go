@@ -0,0 +1,244 @@
+// Package db provides the data-access layer for todos. Handlers depend only
+// on the TodoRepository interface, which keeps the HTTP layer free of any
+// knowledge of the underlying Mongo driver and makes it straightforward to
+// swap in a fake repository for unit tests.
+package db
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// defaultPageSize is used when a caller doesn't request a page size.
+const defaultPageSize = 20
+
+// ErrTodoNotFound is returned when no todo matches the given id and owner.
+var ErrTodoNotFound = errors.New("db: todo not found")
+
+// Cursor is the pagination checkpoint returned as `next_cursor` and echoed
+// back by the caller to fetch the following page. Exactly one of the
+// LastXxx fields is set, matching whichever field the page was sorted by,
+// with LastID as a tiebreaker. The fields are typed (rather than a single
+// interface{}) so a time.Time round-trips through JSON as a time.Time, not
+// as the RFC3339 string it'd decode into without a concrete field to land
+// on — a bare interface{} can't recover that type on unmarshal.
+type Cursor struct {
+	LastID				primitive.ObjectID	`json:"last_id"`
+	LastCreatedAt	time.Time						`json:"last_created_at,omitempty"`
+	LastDueDate		time.Time						`json:"last_due_date,omitempty"`
+	LastTitle			string							`json:"last_title,omitempty"`
+}
+
+// NewCursor builds the pagination checkpoint for the given sort field from
+// the last todo on a page.
+func NewCursor(sortBy string, id primitive.ObjectID, todo schema.Todo) Cursor {
+	switch sortBy {
+	case "due_date":
+		return Cursor{LastID: id, LastDueDate: todo.DueDate}
+	case "title":
+		return Cursor{LastID: id, LastTitle: todo.Title}
+	default:
+		return Cursor{LastID: id, LastCreatedAt: todo.CreatedAt}
+	}
+}
+
+// lastValue returns the field of c matching sortBy, for use as the resume
+// point in FindAll's cursor query.
+func (c Cursor) lastValue(sortBy string) interface{} {
+	switch sortBy {
+	case "due_date":
+		return c.LastDueDate
+	case "title":
+		return c.LastTitle
+	default:
+		return c.LastCreatedAt
+	}
+}
+
+// sortableFields is the whitelist of fields FindAll may sort and paginate
+// on. It exists so a caller can never inject an arbitrary bson sort key.
+var sortableFields = map[string]bool{
+	"created_at":	true,
+	"due_date":		true,
+	"title":			true,
+}
+
+// IsSortable reports whether field is one of the fields FindAll can sort by.
+func IsSortable(field string) bool {
+	return sortableFields[field]
+}
+
+// TodoFilter narrows down and paginates the todos returned by FindAll. A
+// zero value TodoFilter returns the first page of every todo owned by the
+// caller, sorted by created_at ascending.
+type TodoFilter struct {
+	Tag				string
+	Completed	*bool
+	DueBefore	*primitive.DateTime
+	Sort			string	// field name to sort by, defaults to "created_at"
+	Desc			bool		// sort descending instead of ascending
+	Limit			int			// page size, defaults to defaultPageSize
+	Cursor		*Cursor	// resume point from a previous page's next_cursor
+}
+
+// Page is one page of todos plus whether another page follows.
+type Page struct {
+	Todos		[]schema.Todo
+	HasMore	bool
+}
+
+// TodoRepository is the persistence boundary for todos. Every method takes a
+// context so callers can cancel long-running Mongo operations, e.g. when the
+// client disconnects or the server is shutting down, and an ownerID so a
+// caller can never read or mutate another user's todos.
+type TodoRepository interface {
+	FindAll(ctx context.Context, ownerID primitive.ObjectID, filter TodoFilter) (Page, error)
+	Count(ctx context.Context, ownerID primitive.ObjectID, filter TodoFilter) (int64, error)
+	FindByID(ctx context.Context, ownerID, id primitive.ObjectID) (schema.Todo, error)
+	Insert(ctx context.Context, todo schema.Todo) (primitive.ObjectID, error)
+	Update(ctx context.Context, ownerID, id primitive.ObjectID, todo schema.Todo) error
+	Delete(ctx context.Context, ownerID, id primitive.ObjectID) error
+}
+
+// mongoTodoRepository is the TodoRepository backed by mongo-driver.
+type mongoTodoRepository struct {
+	collection *mongo.Collection
+}
+
+// NewTodoRepository returns a TodoRepository backed by the given collection.
+func NewTodoRepository(collection *mongo.Collection) TodoRepository {
+	return &mongoTodoRepository{collection: collection}
+}
+
+// baseQuery builds the bson filter shared by FindAll and Count, i.e.
+// everything except the cursor, which only applies to FindAll's pagination.
+func baseQuery(ownerID primitive.ObjectID, filter TodoFilter) bson.M {
+	query := bson.M{"owner_id": ownerID}
+
+	if filter.Tag != "" {
+		query["tags"] = bson.M{"$in": []string{filter.Tag}}
+	}
+	if filter.Completed != nil {
+		query["completed"] = *filter.Completed
+	}
+	if filter.DueBefore != nil {
+		query["due_date"] = bson.M{"$lte": *filter.DueBefore}
+	}
+
+	return query
+}
+
+func (r *mongoTodoRepository) FindAll(ctx context.Context, ownerID primitive.ObjectID, filter TodoFilter) (Page, error) {
+	query := baseQuery(ownerID, filter)
+
+	sortBy := filter.Sort
+	if sortBy == "" {
+		sortBy = "created_at"
+	}
+
+	dir := 1
+	cmp := "$gt"
+	if filter.Desc {
+		dir = -1
+		cmp = "$lt"
+	}
+
+	if filter.Cursor != nil {
+		lastVal := filter.Cursor.lastValue(sortBy)
+		query["$or"] = []bson.M{
+			{sortBy: bson.M{cmp: lastVal}},
+			{sortBy: lastVal, "_id": bson.M{cmp: filter.Cursor.LastID}},
+		}
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = defaultPageSize
+	}
+
+	// fetch one extra row so we can report HasMore without a second round-trip
+	opts := options.Find().
+		SetSort(bson.D{{Key: sortBy, Value: dir}, {Key: "_id", Value: dir}}).
+		SetLimit(int64(limit) + 1)
+
+	cur, err := r.collection.Find(ctx, query, opts)
+	if err != nil {
+		return Page{}, err
+	}
+	defer cur.Close(ctx)
+
+	todos := []schema.Todo{}
+	if err := cur.All(ctx, &todos); err != nil {
+		return Page{}, err
+	}
+
+	hasMore := len(todos) > limit
+	if hasMore {
+		todos = todos[:limit]
+	}
+
+	return Page{Todos: todos, HasMore: hasMore}, nil
+}
+
+func (r *mongoTodoRepository) Count(ctx context.Context, ownerID primitive.ObjectID, filter TodoFilter) (int64, error) {
+	return r.collection.CountDocuments(ctx, baseQuery(ownerID, filter))
+}
+
+func (r *mongoTodoRepository) FindByID(ctx context.Context, ownerID, id primitive.ObjectID) (schema.Todo, error) {
+	var todo schema.Todo
+	err := r.collection.FindOne(ctx, bson.M{"_id": id, "owner_id": ownerID}).Decode(&todo)
+	return todo, err
+}
+
+func (r *mongoTodoRepository) Insert(ctx context.Context, todo schema.Todo) (primitive.ObjectID, error) {
+	if todo.ID.IsZero() {
+		todo.ID = primitive.NewObjectID()
+	}
+	if todo.UpdatedAt.IsZero() {
+		todo.UpdatedAt = todo.CreatedAt
+	}
+	if _, err := r.collection.InsertOne(ctx, todo); err != nil {
+		return primitive.NilObjectID, err
+	}
+	return todo.ID, nil
+}
+
+func (r *mongoTodoRepository) Update(ctx context.Context, ownerID, id primitive.ObjectID, todo schema.Todo) error {
+	res, err := r.collection.UpdateOne(ctx,
+		bson.M{"_id": id, "owner_id": ownerID},
+		bson.M{"$set": bson.M{
+			"title": 		todo.Title,
+			"note": 		todo.Note,
+			"completed":todo.Completed,
+			"due_date": todo.DueDate,
+			"priority": todo.Priority,
+			"tags": 		todo.Tags,
+			"updated_at":time.Now(),
+		}},
+	)
+	if err != nil {
+		return err
+	}
+	if res.MatchedCount == 0 {
+		return ErrTodoNotFound
+	}
+	return nil
+}
+
+func (r *mongoTodoRepository) Delete(ctx context.Context, ownerID, id primitive.ObjectID) error {
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id, "owner_id": ownerID})
+	if err != nil {
+		return err
+	}
+	if res.DeletedCount == 0 {
+		return ErrTodoNotFound
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package db
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// ErrUserNotFound is returned when no user matches the requested email.
+var ErrUserNotFound = errors.New("db: user not found")
+
+// UserRepository is the persistence boundary for user accounts.
+type UserRepository interface {
+	FindByEmail(ctx context.Context, email string) (schema.User, error)
+	Insert(ctx context.Context, user schema.User) error
+}
+
+// mongoUserRepository is the UserRepository backed by mongo-driver.
+type mongoUserRepository struct {
+	collection *mongo.Collection
+}
+
+// NewUserRepository returns a UserRepository backed by the given collection.
+func NewUserRepository(collection *mongo.Collection) UserRepository {
+	return &mongoUserRepository{collection: collection}
+}
+
+func (r *mongoUserRepository) FindByEmail(ctx context.Context, email string) (schema.User, error) {
+	var user schema.User
+	err := r.collection.FindOne(ctx, bson.M{"email": email}).Decode(&user)
+	if err == mongo.ErrNoDocuments {
+		return schema.User{}, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (r *mongoUserRepository) Insert(ctx context.Context, user schema.User) error {
+	_, err := r.collection.InsertOne(ctx, user)
+	return err
+}
@@ -0,0 +1,118 @@
+// Package events is a small in-process pub/sub hub that lets the SSE
+// handler push todo changes to connected clients as they happen.
+package events
+
+import (
+	"sync"
+
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// EventType identifies what happened to a todo.
+type EventType string
+
+const (
+	Created	EventType = "created"
+	Updated	EventType = "updated"
+	Deleted	EventType = "deleted"
+)
+
+// subscriberBuffer is the per-client channel size. A client that falls this
+// far behind has events dropped for it rather than blocking publishers.
+const subscriberBuffer = 16
+
+// replayBufferSize bounds how many past events the hub keeps around so a
+// reconnecting client can replay what it missed via Last-Event-ID.
+const replayBufferSize = 256
+
+// Event is one todo change, published to every subscriber owning that todo.
+type Event struct {
+	ID				int64							`json:"-"`
+	Type			EventType					`json:"type"`
+	Todo			schema.Todo				`json:"todo"`
+	ownerID		primitive.ObjectID
+}
+
+type subscriber struct {
+	ch			chan Event
+	ownerID	primitive.ObjectID
+}
+
+// Hub fans out todo events to subscribers and keeps one bounded replay
+// buffer, shared by all owners, for reconnecting clients.
+type Hub struct {
+	mu					sync.Mutex
+	nextID			int64
+	subscribers	map[int64]subscriber
+	nextSubID		int64
+	buffer			[]Event
+}
+
+// NewHub returns an empty Hub.
+func NewHub() *Hub {
+	return &Hub{subscribers: make(map[int64]subscriber)}
+}
+
+// Publish records an event and fans it out to every subscriber that owns
+// todo. Slow subscribers whose buffer is full simply miss the event; they
+// can catch up on reconnect via Last-Event-ID.
+func (h *Hub) Publish(ownerID primitive.ObjectID, eventType EventType, todo schema.Todo) {
+	h.mu.Lock()
+	h.nextID++
+	event := Event{ID: h.nextID, Type: eventType, Todo: todo, ownerID: ownerID}
+
+	h.buffer = append(h.buffer, event)
+	if len(h.buffer) > replayBufferSize {
+		h.buffer = h.buffer[len(h.buffer)-replayBufferSize:]
+	}
+
+	subs := make([]subscriber, 0, len(h.subscribers))
+	for _, s := range h.subscribers {
+		if s.ownerID == ownerID {
+			subs = append(subs, s)
+		}
+	}
+	h.mu.Unlock()
+
+	for _, s := range subs {
+		select {
+		case s.ch <- event:
+		default:
+			// slow consumer: drop rather than block the publisher
+		}
+	}
+}
+
+// SubscribeFrom registers a new subscriber scoped to ownerID and returns the
+// buffered events it missed (ID > afterID, oldest first) together with its
+// live event channel and an unsubscribe func the caller must defer. The
+// replay snapshot and the subscription are taken under a single lock so an
+// event published concurrently lands in exactly one of the two: either it's
+// already in the replay snapshot, or the subscriber is already registered to
+// receive it, never both.
+func (h *Hub) SubscribeFrom(ownerID primitive.ObjectID, afterID int64) ([]Event, <-chan Event, func()) {
+	h.mu.Lock()
+
+	missed := make([]Event, 0)
+	for _, e := range h.buffer {
+		if e.ownerID == ownerID && e.ID > afterID {
+			missed = append(missed, e)
+		}
+	}
+
+	id := h.nextSubID
+	h.nextSubID++
+	ch := make(chan Event, subscriberBuffer)
+	h.subscribers[id] = subscriber{ch: ch, ownerID: ownerID}
+
+	h.mu.Unlock()
+
+	unsubscribe := func() {
+		h.mu.Lock()
+		delete(h.subscribers, id)
+		h.mu.Unlock()
+	}
+
+	return missed, ch, unsubscribe
+}
@@ -0,0 +1,50 @@
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestSubscribeFromNoDuplicate guards against a regression where Replay and
+// Subscribe were separate, non-atomic steps: an event published in between
+// them showed up both in the replay snapshot and, a second time, on the
+// subscriber's channel.
+func TestSubscribeFromNoDuplicate(t *testing.T) {
+	h := NewHub()
+	owner := primitive.NewObjectID()
+
+	h.Publish(owner, Created, schema.Todo{OwnerID: owner, Title: "before"})
+
+	missed, ch, unsubscribe := h.SubscribeFrom(owner, 0)
+	defer unsubscribe()
+
+	if len(missed) != 1 || missed[0].Todo.Title != "before" {
+		t.Fatalf("missed = %+v, want one event titled %q", missed, "before")
+	}
+
+	select {
+	case e := <-ch:
+		t.Fatalf("got unexpected live event for something already in the replay snapshot: %+v", e)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	h.Publish(owner, Created, schema.Todo{OwnerID: owner, Title: "after"})
+
+	select {
+	case e := <-ch:
+		if e.Todo.Title != "after" {
+			t.Fatalf("ch delivered %+v, want title %q", e, "after")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for live event")
+	}
+
+	allMissed, _, unsubscribeAll := h.SubscribeFrom(owner, 0)
+	unsubscribeAll()
+	if len(allMissed) != 2 {
+		t.Fatalf("buffer has %d events, want 2", len(allMissed))
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/thedevsaddam/renderer"
+	"github.com/wesley-lewis/todo-list-golang/auth"
+	"github.com/wesley-lewis/todo-list-golang/db"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// credentials is the request body for both register and login.
+type credentials struct {
+	Email			string	`json:"email"`
+	Password	string	`json:"password"`
+}
+
+// AuthHandler exposes the registration and login endpoints.
+type AuthHandler struct {
+	users		db.UserRepository
+	issuer	*auth.TokenIssuer
+	rnd			*renderer.Render
+}
+
+// NewAuthHandler wires an AuthHandler to the given user repository, token
+// issuer and renderer.
+func NewAuthHandler(users db.UserRepository, issuer *auth.TokenIssuer, rnd *renderer.Render) *AuthHandler {
+	return &AuthHandler{users: users, issuer: issuer, rnd: rnd}
+}
+
+// Register creates a new user account with a bcrypt-hashed password.
+func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
+	var c credentials
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, err)
+		return
+	}
+
+	if c.Email == "" || c.Password == "" {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The email and password fields are required",
+		})
+		return
+	}
+
+	_, err := h.users.FindByEmail(r.Context(), c.Email)
+	switch {
+	case err == nil:
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "A user with that email already exists",
+		})
+		return
+	case errors.Is(err, db.ErrUserNotFound):
+		// expected: no existing user, free to register
+	default:
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to look up user",
+			"error": err,
+		})
+		return
+	}
+
+	passwordHash, err := auth.HashPassword(c.Password)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to hash password",
+			"error": err,
+		})
+		return
+	}
+
+	user := schema.User{
+		ID: 						primitive.NewObjectID(),
+		Email: 					c.Email,
+		PasswordHash:		passwordHash,
+		CreatedAt: 			time.Now(),
+	}
+
+	if err := h.users.Insert(r.Context(), user); err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to create user",
+			"error": err,
+		})
+		return
+	}
+
+	h.rnd.JSON(w, http.StatusCreated, renderer.M{
+		"message": "User registered successfully",
+		"user_id": user.ID.Hex(),
+	})
+}
+
+// Login verifies credentials and issues a signed JWT on success.
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var c credentials
+	if err := json.NewDecoder(r.Body).Decode(&c); err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, err)
+		return
+	}
+
+	user, err := h.users.FindByEmail(r.Context(), c.Email)
+	if err != nil || !auth.ComparePassword(user.PasswordHash, c.Password) {
+		h.rnd.JSON(w, http.StatusUnauthorized, renderer.M{
+			"message": "Invalid email or password",
+		})
+		return
+	}
+
+	token, err := h.issuer.Issue(user.ID.Hex())
+	if err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to issue token",
+			"error": err,
+		})
+		return
+	}
+
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"message": "Login successful",
+		"token": 	token,
+	})
+}
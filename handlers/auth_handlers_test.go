@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thedevsaddam/renderer"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+)
+
+func registerRequest(t *testing.T, h *AuthHandler, email, password string) *httptest.ResponseRecorder {
+	t.Helper()
+	body, err := json.Marshal(credentials{Email: email, Password: password})
+	if err != nil {
+		t.Fatalf("marshal request: %v", err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/register", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	h.Register(rec, req)
+	return rec
+}
+
+func TestRegister_Success(t *testing.T) {
+	h := NewAuthHandler(newFakeUserRepository(), nil, renderer.New())
+
+	rec := registerRequest(t, h, "new@example.com", "hunter2")
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
+	}
+}
+
+func TestRegister_EmailAlreadyExists(t *testing.T) {
+	users := newFakeUserRepository()
+	users.users["taken@example.com"] = schema.User{Email: "taken@example.com"}
+	h := NewAuthHandler(users, nil, renderer.New())
+
+	rec := registerRequest(t, h, "taken@example.com", "hunter2")
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+// TestRegister_LookupError makes sure a real lookup failure (e.g. a dropped
+// DB connection) is reported as a failure, not silently treated the same as
+// "a user with that email already exists".
+func TestRegister_LookupError(t *testing.T) {
+	users := newFakeUserRepository()
+	users.findErr = errors.New("connection refused")
+	h := NewAuthHandler(users, nil, renderer.New())
+
+	rec := registerRequest(t, h, "whoever@example.com", "hunter2")
+
+	if rec.Code == http.StatusBadRequest {
+		t.Fatalf("status = %d, lookup error must not be reported as \"already exists\"", rec.Code)
+	}
+
+	var resp renderer.M
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp["message"] == "A user with that email already exists" {
+		t.Fatalf("lookup error surfaced as \"already exists\": %v", resp)
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/wesley-lewis/todo-list-golang/db"
+)
+
+// encodeCursor renders a db.Cursor as the opaque string handed back to
+// clients as `next_cursor`.
+func encodeCursor(c db.Cursor) (string, error) {
+	raw, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(raw), nil
+}
+
+// decodeCursor reverses encodeCursor.
+func decodeCursor(s string) (db.Cursor, error) {
+	var c db.Cursor
+	raw, err := base64.URLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(raw, &c)
+	return c, err
+}
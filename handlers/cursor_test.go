@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/wesley-lewis/todo-list-golang/db"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TestCursorRoundTrip guards against a regression where a sort field's
+// concrete Go type (e.g. time.Time for created_at/due_date) got lost on the
+// encodeCursor/decodeCursor round-trip and came back as a bare string,
+// silently breaking pagination past page 1 for every sort but title.
+func TestCursorRoundTrip(t *testing.T) {
+	id := primitive.NewObjectID()
+	due := time.Date(2026, 8, 1, 12, 0, 0, 0, time.UTC)
+	created := time.Date(2026, 7, 1, 9, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		name		string
+		sortBy	string
+		todo		schema.Todo
+	}{
+		{"created_at", "created_at", schema.Todo{CreatedAt: created}},
+		{"due_date", "due_date", schema.Todo{DueDate: due}},
+		{"title", "title", schema.Todo{Title: "zzz"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			want := db.NewCursor(c.sortBy, id, c.todo)
+
+			encoded, err := encodeCursor(want)
+			if err != nil {
+				t.Fatalf("encodeCursor: %v", err)
+			}
+
+			got, err := decodeCursor(encoded)
+			if err != nil {
+				t.Fatalf("decodeCursor: %v", err)
+			}
+
+			if !got.LastCreatedAt.Equal(want.LastCreatedAt) {
+				t.Errorf("LastCreatedAt = %v, want %v", got.LastCreatedAt, want.LastCreatedAt)
+			}
+			if !got.LastDueDate.Equal(want.LastDueDate) {
+				t.Errorf("LastDueDate = %v, want %v", got.LastDueDate, want.LastDueDate)
+			}
+			if got.LastTitle != want.LastTitle {
+				t.Errorf("LastTitle = %q, want %q", got.LastTitle, want.LastTitle)
+			}
+			if got.LastID != want.LastID {
+				t.Errorf("LastID = %v, want %v", got.LastID, want.LastID)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/wesley-lewis/todo-list-golang/schema"
+)
+
+// weakETag computes a weak ETag over a page of todos from their IDs and
+// updated_at timestamps, so it changes whenever the page's contents do.
+func weakETag(todos []schema.Todo) string {
+	h := sha256.New()
+	for _, t := range todos {
+		fmt.Fprintf(h, "%s:%d;", t.ID.Hex(), t.UpdatedAt.UnixNano())
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil)) + `"`
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+
+	"github.com/wesley-lewis/todo-list-golang/db"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// fakeTodoRepository is an in-memory db.TodoRepository for exercising
+// handlers without a real Mongo instance. It's deliberately minimal: FindAll
+// and Count match on owner only and ignore the rest of the filter (tag,
+// completed, due_before, sort, limit, cursor), and FindAll never reports
+// HasMore. The handler tests only assert on validation and not-found paths,
+// which don't depend on the filter.
+type fakeTodoRepository struct {
+	todos map[primitive.ObjectID]schema.Todo
+}
+
+func newFakeTodoRepository() *fakeTodoRepository {
+	return &fakeTodoRepository{todos: make(map[primitive.ObjectID]schema.Todo)}
+}
+
+func (f *fakeTodoRepository) FindAll(ctx context.Context, ownerID primitive.ObjectID, filter db.TodoFilter) (db.Page, error) {
+	todos := []schema.Todo{}
+	for _, t := range f.todos {
+		if t.OwnerID == ownerID {
+			todos = append(todos, t)
+		}
+	}
+	return db.Page{Todos: todos}, nil
+}
+
+func (f *fakeTodoRepository) Count(ctx context.Context, ownerID primitive.ObjectID, filter db.TodoFilter) (int64, error) {
+	var n int64
+	for _, t := range f.todos {
+		if t.OwnerID == ownerID {
+			n++
+		}
+	}
+	return n, nil
+}
+
+func (f *fakeTodoRepository) FindByID(ctx context.Context, ownerID, id primitive.ObjectID) (schema.Todo, error) {
+	t, ok := f.todos[id]
+	if !ok || t.OwnerID != ownerID {
+		return schema.Todo{}, errors.New("fake: todo not found")
+	}
+	return t, nil
+}
+
+func (f *fakeTodoRepository) Insert(ctx context.Context, todo schema.Todo) (primitive.ObjectID, error) {
+	f.todos[todo.ID] = todo
+	return todo.ID, nil
+}
+
+func (f *fakeTodoRepository) Update(ctx context.Context, ownerID, id primitive.ObjectID, todo schema.Todo) error {
+	existing, ok := f.todos[id]
+	if !ok || existing.OwnerID != ownerID {
+		return db.ErrTodoNotFound
+	}
+	todo.ID = id
+	todo.OwnerID = ownerID
+	f.todos[id] = todo
+	return nil
+}
+
+func (f *fakeTodoRepository) Delete(ctx context.Context, ownerID, id primitive.ObjectID) error {
+	existing, ok := f.todos[id]
+	if !ok || existing.OwnerID != ownerID {
+		return db.ErrTodoNotFound
+	}
+	delete(f.todos, id)
+	return nil
+}
+
+// fakeUserRepository is an in-memory db.UserRepository for handler tests.
+// Setting findErr makes FindByEmail return that error instead of looking the
+// email up, so tests can exercise the "lookup itself failed" branch of
+// Register that's distinct from both "found" and db.ErrUserNotFound.
+type fakeUserRepository struct {
+	users		map[string]schema.User
+	findErr	error
+}
+
+func newFakeUserRepository() *fakeUserRepository {
+	return &fakeUserRepository{users: make(map[string]schema.User)}
+}
+
+func (f *fakeUserRepository) FindByEmail(ctx context.Context, email string) (schema.User, error) {
+	if f.findErr != nil {
+		return schema.User{}, f.findErr
+	}
+	u, ok := f.users[email]
+	if !ok {
+		return schema.User{}, db.ErrUserNotFound
+	}
+	return u, nil
+}
+
+func (f *fakeUserRepository) Insert(ctx context.Context, user schema.User) error {
+	f.users[user.Email] = user
+	return nil
+}
@@ -0,0 +1,19 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/thedevsaddam/renderer"
+)
+
+// HomeHandler renders the static landing page.
+func HomeHandler(rnd *renderer.Render) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil); err != nil {
+			rnd.JSON(w, http.StatusInternalServerError, renderer.M{
+				"message": "Failed to render home page",
+				"error": err,
+			})
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/thedevsaddam/renderer"
+	"github.com/wesley-lewis/todo-list-golang/auth"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type contextKey string
+
+// ownerIDContextKey is where AuthRequired stores the authenticated user's ID
+// for downstream handlers to read with ownerIDFromContext.
+const ownerIDContextKey contextKey = "owner_id"
+
+// AuthRequired validates the request's "Authorization: Bearer <token>"
+// header against issuer and, on success, stores the token's user_id claim in
+// the request context as the todo owner.
+func AuthRequired(issuer *auth.TokenIssuer, rnd *renderer.Render) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			tokenString := strings.TrimPrefix(header, "Bearer ")
+			if tokenString == "" || tokenString == header {
+				rnd.JSON(w, http.StatusUnauthorized, renderer.M{
+					"message": "Missing bearer token",
+				})
+				return
+			}
+
+			userID, err := issuer.UserID(tokenString)
+			if err != nil {
+				rnd.JSON(w, http.StatusUnauthorized, renderer.M{
+					"message": "Invalid or expired token",
+				})
+				return
+			}
+
+			ownerID, err := primitive.ObjectIDFromHex(userID)
+			if err != nil {
+				rnd.JSON(w, http.StatusUnauthorized, renderer.M{
+					"message": "Invalid or expired token",
+				})
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ownerIDContextKey, ownerID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// ownerIDFromContext retrieves the owner ID stored by AuthRequired.
+func ownerIDFromContext(ctx context.Context) (primitive.ObjectID, bool) {
+	ownerID, ok := ctx.Value(ownerIDContextKey).(primitive.ObjectID)
+	return ownerID, ok
+}
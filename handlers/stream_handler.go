@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wesley-lewis/todo-list-golang/events"
+)
+
+// pingInterval is how often a `:ping` comment is written to keep
+// intermediary proxies from closing an idle SSE connection.
+const pingInterval = 15 * time.Second
+
+// StreamHandler serves the Server-Sent Events feed of todo changes.
+type StreamHandler struct {
+	hub *events.Hub
+}
+
+// NewStreamHandler wires a StreamHandler to the given hub.
+func NewStreamHandler(hub *events.Hub) *StreamHandler {
+	return &StreamHandler{hub: hub}
+}
+
+// Stream holds the connection open and pushes `event:`/`data:` frames for
+// every todo created, updated or deleted by the caller. A `Last-Event-ID`
+// header replays anything the client missed from the hub's bounded buffer
+// before switching over to live events.
+func (h *StreamHandler) Stream(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := ownerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "missing authentication context", http.StatusUnauthorized)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	// The server's shared WriteTimeout is a hard deadline set once when the
+	// request header is read; it isn't reset by later writes, so without
+	// this every stream would be cut ~60s after opening regardless of the
+	// :ping keepalive below. Streams are long-lived by design, so they opt
+	// out of that deadline entirely.
+	if err := http.NewResponseController(w).SetWriteDeadline(time.Time{}); err != nil {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	var lastEventID int64
+	raw := r.Header.Get("Last-Event-ID")
+	if raw != "" {
+		lastEventID, _ = strconv.ParseInt(raw, 10, 64)
+	}
+
+	// replay and subscribe atomically so an event published in between can't
+	// be delivered twice, once via the replay snapshot and once via ch
+	missed, ch, unsubscribe := h.hub.SubscribeFrom(ownerID, lastEventID)
+	defer unsubscribe()
+
+	// a fresh connection (no Last-Event-ID) has missed nothing, so it only
+	// gets live events
+	if raw == "" {
+		missed = nil
+	}
+
+	for _, e := range missed {
+		if !writeEvent(w, e) {
+			return
+		}
+	}
+	flusher.Flush()
+
+	ticker := time.NewTicker(pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-ch:
+			if !writeEvent(w, e) {
+				return
+			}
+			flusher.Flush()
+		case <-ticker.C:
+			if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent renders a single SSE frame, reporting whether the write
+// succeeded (false means the connection is gone and the caller should stop).
+func writeEvent(w http.ResponseWriter, e events.Event) bool {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return true // skip a bad event rather than killing the connection
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", e.ID, e.Type, data)
+	return err == nil
+}
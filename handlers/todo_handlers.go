@@ -0,0 +1,320 @@
+// Package handlers contains the HTTP layer. Handlers depend only on the
+// db.TodoRepository interface, not on any concrete database driver, so they
+// can be exercised in tests against a fake repository.
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi"
+	"github.com/thedevsaddam/renderer"
+	"github.com/wesley-lewis/todo-list-golang/db"
+	"github.com/wesley-lewis/todo-list-golang/events"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// TodoHandler exposes the todo CRUD endpoints on top of a TodoRepository,
+// publishing every change to hub so the SSE stream can push it to clients.
+type TodoHandler struct {
+	repo	db.TodoRepository
+	hub		*events.Hub
+	rnd		*renderer.Render
+}
+
+// NewTodoHandler wires a TodoHandler to the given repository, event hub and
+// renderer.
+func NewTodoHandler(repo db.TodoRepository, hub *events.Hub, rnd *renderer.Render) *TodoHandler {
+	return &TodoHandler{repo: repo, hub: hub, rnd: rnd}
+}
+
+// Routes mounts the todo endpoints onto their own sub-router.
+func (h *TodoHandler) Routes() http.Handler {
+	rg := chi.NewRouter()
+	rg.Group(func(r chi.Router) {
+		r.Get("/", h.FetchTodos)
+		r.Post("/", h.CreateTodo)
+		r.Put("/{id}", h.UpdateTodo)
+		r.Delete("/{id}", h.DeleteTodo)
+	})
+
+	return rg
+}
+
+func (h *TodoHandler) CreateTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := ownerIDFromContext(r.Context())
+	if !ok {
+		h.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "Missing authentication context"})
+		return
+	}
+
+	var t schema.Todo // for communicating with the frontend we require a JSON object hence we use schema.Todo
+
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, err)
+		return
+	}
+
+	// validation of title
+	if t.Title == "" {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The title field is important",
+		})
+		return
+	}
+
+	// default to medium priority when the caller doesn't specify one
+	if t.Priority == "" {
+		t.Priority = schema.PriorityMedium
+	} else if !t.Priority.IsValid() {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The priority field must be one of low, medium, high",
+		})
+		return
+	}
+
+	t.ID = primitive.NewObjectID()
+	t.OwnerID = ownerID
+	t.Completed = false
+	t.CreatedAt = time.Now()
+
+	id, err := h.repo.Insert(r.Context(), t)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to save todo",
+			"error": err,
+		})
+		return
+	}
+
+	t.ID = id
+	h.hub.Publish(ownerID, events.Created, t)
+
+	// if everything is done properly
+	h.rnd.JSON(w, http.StatusCreated, renderer.M{
+		"message": "Todo created successfully",
+		"todo_id": id.Hex(),
+	})
+}
+
+func (h *TodoHandler) UpdateTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := ownerIDFromContext(r.Context())
+	if !ok {
+		h.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "Missing authentication context"})
+		return
+	}
+
+	// chi.URLParam is used to retrieve values from the url
+	id, err := primitive.ObjectIDFromHex(strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The id is invalid",
+		})
+		return
+	}
+
+	var t schema.Todo
+
+	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, err)
+		return
+	}
+
+	// validation of the title field
+	if t.Title == "" {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The title field is required",
+		})
+		return
+	}
+
+	// validation of the priority field
+	if t.Priority == "" {
+		t.Priority = schema.PriorityMedium
+	} else if !t.Priority.IsValid() {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The priority field must be one of low, medium, high",
+		})
+		return
+	}
+
+	if err := h.repo.Update(r.Context(), ownerID, id, t); err != nil {
+		if errors.Is(err, db.ErrTodoNotFound) {
+			h.rnd.JSON(w, http.StatusNotFound, renderer.M{
+				"message": "Todo not found",
+			})
+			return
+		}
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to update todo",
+			"error": err,
+		})
+		return
+	}
+
+	t.ID = id
+	t.OwnerID = ownerID
+	h.hub.Publish(ownerID, events.Updated, t)
+
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"message": "Todo updated successfully",
+	})
+}
+
+// FetchTodos lists todos, optionally filtered by the `tag`, `completed` and
+// `due_before` query parameters, sorted by `sort` (one of created_at,
+// due_date, title; defaults to created_at) and `order` (asc or desc,
+// defaults to asc). `due_before` expects an RFC3339 timestamp.
+//
+// Results are paginated: `limit` caps the page size (default 20) and
+// `cursor` resumes from a previous page's `next_cursor`. The response is
+// cached with a weak ETag over the page's IDs and updated_at values, so a
+// matching `If-None-Match` gets a 304 with no body.
+func (h *TodoHandler) FetchTodos(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := ownerIDFromContext(r.Context())
+	if !ok {
+		h.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "Missing authentication context"})
+		return
+	}
+
+	sortBy := r.URL.Query().Get("sort")
+	if sortBy != "" && !db.IsSortable(sortBy) {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "sort must be one of created_at, due_date, title",
+		})
+		return
+	}
+
+	filter := db.TodoFilter{
+		Tag: 	r.URL.Query().Get("tag"),
+		Sort:	sortBy,
+		Desc:	r.URL.Query().Get("order") == "desc",
+	}
+
+	if completed := r.URL.Query().Get("completed"); completed != "" {
+		val := completed == "true"
+		filter.Completed = &val
+	}
+
+	if dueBefore := r.URL.Query().Get("due_before"); dueBefore != "" {
+		t, err := time.Parse(time.RFC3339, dueBefore)
+		if err != nil {
+			h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+				"message": "due_before must be an RFC3339 timestamp",
+			})
+			return
+		}
+		dt := primitive.NewDateTimeFromTime(t)
+		filter.DueBefore = &dt
+	}
+
+	if limit := r.URL.Query().Get("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n <= 0 {
+			h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+				"message": "limit must be a positive integer",
+			})
+			return
+		}
+		filter.Limit = n
+	}
+
+	if cursor := r.URL.Query().Get("cursor"); cursor != "" {
+		c, err := decodeCursor(cursor)
+		if err != nil {
+			h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+				"message": "cursor is invalid",
+			})
+			return
+		}
+		filter.Cursor = &c
+	}
+
+	page, err := h.repo.FindAll(r.Context(), ownerID, filter)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to fetch todo",
+			"error": err,
+		})
+		return
+	}
+
+	etag := weakETag(page.Todos)
+	w.Header().Set("Cache-Control", "private, max-age=10")
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	total, err := h.repo.Count(r.Context(), ownerID, filter)
+	if err != nil {
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to count todos",
+			"error": err,
+		})
+		return
+	}
+
+	var nextCursor string
+	if page.HasMore && len(page.Todos) > 0 {
+		last := page.Todos[len(page.Todos)-1]
+		nextCursor, err = encodeCursor(db.NewCursor(filter.Sort, last.ID, last))
+		if err != nil {
+			h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+				"message": "Failed to build next_cursor",
+				"error": err,
+			})
+			return
+		}
+	}
+
+	// the data is sent as json
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"data": 				page.Todos,
+		"next_cursor":	nextCursor,
+		"total": 				total,
+	})
+}
+
+func (h *TodoHandler) DeleteTodo(w http.ResponseWriter, r *http.Request) {
+	ownerID, ok := ownerIDFromContext(r.Context())
+	if !ok {
+		h.rnd.JSON(w, http.StatusUnauthorized, renderer.M{"message": "Missing authentication context"})
+		return
+	}
+
+	// the ID field will be used to find that particular record within the database and delete it
+	id, err := primitive.ObjectIDFromHex(strings.TrimSpace(chi.URLParam(r, "id")))
+	if err != nil {
+		h.rnd.JSON(w, http.StatusBadRequest, renderer.M{
+			"message": "The id is invalid",
+		})
+		return
+	}
+
+	if err := h.repo.Delete(r.Context(), ownerID, id); err != nil {
+		if errors.Is(err, db.ErrTodoNotFound) {
+			h.rnd.JSON(w, http.StatusNotFound, renderer.M{
+				"message": "Todo not found",
+			})
+			return
+		}
+		h.rnd.JSON(w, http.StatusProcessing, renderer.M{
+			"message": "Failed to delete todo",
+			"error": err,
+		})
+		return
+	}
+
+	h.hub.Publish(ownerID, events.Deleted, schema.Todo{ID: id, OwnerID: ownerID})
+
+	h.rnd.JSON(w, http.StatusOK, renderer.M{
+		"message": "Todo deleted successfully",
+	})
+}
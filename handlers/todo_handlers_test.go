@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi"
+	"github.com/thedevsaddam/renderer"
+	"github.com/wesley-lewis/todo-list-golang/events"
+	"github.com/wesley-lewis/todo-list-golang/schema"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func withOwner(req *http.Request, ownerID primitive.ObjectID) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), ownerIDContextKey, ownerID))
+}
+
+func withURLParam(req *http.Request, key, value string) *http.Request {
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add(key, value)
+	return req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+}
+
+func TestFetchTodos_InvalidSort(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	h := NewTodoHandler(newFakeTodoRepository(), events.NewHub(), renderer.New())
+
+	req := withOwner(httptest.NewRequest(http.MethodGet, "/?sort=owner_id", nil), ownerID)
+	rec := httptest.NewRecorder()
+	h.FetchTodos(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestFetchTodos_ValidSort(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	h := NewTodoHandler(newFakeTodoRepository(), events.NewHub(), renderer.New())
+
+	req := withOwner(httptest.NewRequest(http.MethodGet, "/?sort=due_date", nil), ownerID)
+	rec := httptest.NewRecorder()
+	h.FetchTodos(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestUpdateTodo_NotFound(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	h := NewTodoHandler(newFakeTodoRepository(), events.NewHub(), renderer.New())
+
+	body, _ := json.Marshal(schema.Todo{Title: "still missing"})
+	req := httptest.NewRequest(http.MethodPut, "/"+primitive.NewObjectID().Hex(), bytes.NewReader(body))
+	req = withOwner(req, ownerID)
+	req = withURLParam(req, "id", primitive.NewObjectID().Hex())
+
+	rec := httptest.NewRecorder()
+	h.UpdateTodo(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+func TestDeleteTodo_NotFound(t *testing.T) {
+	ownerID := primitive.NewObjectID()
+	h := NewTodoHandler(newFakeTodoRepository(), events.NewHub(), renderer.New())
+
+	req := httptest.NewRequest(http.MethodDelete, "/"+primitive.NewObjectID().Hex(), nil)
+	req = withOwner(req, ownerID)
+	req = withURLParam(req, "id", primitive.NewObjectID().Hex())
+
+	rec := httptest.NewRecorder()
+	h.DeleteTodo(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+}
+
+// TestDeleteTodo_WrongOwner makes sure deleting another user's todo 404s
+// instead of succeeding, since the repository filters by owner_id.
+func TestDeleteTodo_WrongOwner(t *testing.T) {
+	owner := primitive.NewObjectID()
+	other := primitive.NewObjectID()
+	repo := newFakeTodoRepository()
+	todoID := primitive.NewObjectID()
+	repo.todos[todoID] = schema.Todo{ID: todoID, OwnerID: owner}
+
+	h := NewTodoHandler(repo, events.NewHub(), renderer.New())
+
+	req := httptest.NewRequest(http.MethodDelete, "/"+todoID.Hex(), nil)
+	req = withOwner(req, other)
+	req = withURLParam(req, "id", todoID.Hex())
+
+	rec := httptest.NewRecorder()
+	h.DeleteTodo(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	if _, ok := repo.todos[todoID]; !ok {
+		t.Fatalf("todo owned by another user was deleted")
+	}
+}
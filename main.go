@@ -1,250 +1,112 @@
 package main
 
 import (
-	"encoding/json"
+	"context"
+	"errors"
 	"log" // to log the errors
 	"net/http" // will help to create server in golang
-	"strings" 
-	"time" // timestamp to put in the database
-	"context"
 	"os"
 	"os/signal"
+	"syscall"
+	"time" // connect, server and shutdown timeouts
 
 	"github.com/go-chi/chi"
 	"github.com/go-chi/chi/middleware"
 	"github.com/thedevsaddam/renderer"
-	mgo "gopkg.in/mgo.v2"
-	"gopkg.in/mgo.v2/bson"
+	"github.com/wesley-lewis/todo-list-golang/auth"
+	"github.com/wesley-lewis/todo-list-golang/config"
+	"github.com/wesley-lewis/todo-list-golang/db"
+	"github.com/wesley-lewis/todo-list-golang/events"
+	"github.com/wesley-lewis/todo-list-golang/handlers"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 var rnd *renderer.Render
-var db *mgo.Database
-
-const (
-	hostName 				string = "localhost:27017"			// go will connect to mongodb on this host
-	dbName 					string = "demo_todo"						// the database name in mongodb
-	collectionName 	string = "todo"									// the collection name withing the database "demo_todo"
-	port 						string = ":9000"								// the port number on which the server will be running to accept incoming client requests
-)	
-
-
-// The struct tag is used for specifying the field name in the BSON document that corresponds to the struct field. 
-// For eg in the below todoModel struct: The 'Title' in the struct will be mapped to 'title' in the bson document 
-// omitempty will not marshal the ID meaning if the ID field is empty then it won't be converted to the bson format
-type (
-	todoModel struct {
-		ID							bson.ObjectId	`bson:"_id,omitempty"`		
-		Title						string 				`bson:"title"`
-		Completed				bool					`bson:"completed"`
-		CreatedAt				time.Time			`bson:"created_at"`
-	}
-
-	todo struct {
-		ID							string 			`json:"id"`
-		Title						string 			`json:"title"`
-		Completed				bool				`json:"completed"`
-		CreatedAt				time.Time		`json:"created_at"`
-	}
-)
 
-// This function will execute before the main() function initializing the variables and connecting to the database
+// This function will execute before the main() function initializing the renderer.
 // You would notice that this function isn't called anywhere yet it runs first. This is the power of the init function.
 func init() {
 	rnd = renderer.New()
-	sess, err := mgo.Dial(hostName)
-	checkErr(err)
-	sess.SetMode(mgo.Monotonic, true)
-	db = sess.DB(dbName)
-}
-
-func homeHandler(w http.ResponseWriter, r *http.Request) {
-	err := rnd.Template(w, http.StatusOK, []string{"static/home.tpl"}, nil)
-	checkErr(err)
 }
 
-func createTodo(w http.ResponseWriter, r *http.Request) {
-	var t todo 		// for communcating with the frontend we require a JSON object hence we use todo
-
-	if err := json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusProcessing, err)
-		return
-	}
-
-	// validation of title
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The title field is important",
-		})
-		return
-	}
-
-	// if the input is correct, we will create a todo
-	// for communicating with the database we will require a BSON object. Hence we will be creating a todoModel
-	tm := todoModel{
-		ID: 				bson.NewObjectId(),
-		Title: 			t.Title,
-		Completed:	false,
-		CreatedAt: time.Now(),
-	}
-
-	if err := db.C(collectionName).Insert(&tm); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "Failed to save todo",
-			"error": err,
-		})
-		return
-	}
-
-	// if everything is done properly
-	rnd.JSON(w, http.StatusCreated, renderer.M{
-		"message": "Todo created successfully",
-		"todo_id": tm.ID.Hex(),
-	})
-}
-
-func updateTodo(w http.ResponseWriter, r *http.Request) {
-	// chi.URLParam is used to retrive values from the url 
-	id := strings.TrimSpace(chi.URLParam(r, "id"))
-
-	// checks if the id supplied via the url is an valid ObjectId in Hex format
-	if !bson.IsObjectIdHex(id) {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The id is invalid",
-		})
-		return
-	}
+func main() {
+	cfg := config.Load()
 
-	var t todo
-	
-	if err:=json.NewDecoder(r.Body).Decode(&t); err != nil {
-		rnd.JSON(w, http.StatusProcessing, err)
-		return
-	}
+	// buffered so the signal isn't dropped if we're not yet listening
+	stopChan := make(chan os.Signal, 1)
+	signal.Notify(stopChan, os.Interrupt, syscall.SIGTERM)
 
-	// validation of the title field
-	if t.Title == "" {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The title field is required",
-		})
-		return
-	}
-
-	// if input is okay, update the todo
-	// We provide bson.M{} as it can communicate with the database. 
-	if err:=db.C(collectionName).
-					Update(
-						bson.M{"_id": bson.ObjectIdHex(id)}, // this value is used to select the object in the database
-						bson.M{"title": t.Title, "completed":t.Completed},	// this value is used to update the actual value in the database
-					); err != nil {
-						rnd.JSON(w, http.StatusProcessing, renderer.M{
-							"message": "Failed to update todo",
-							"error": err,
-						})
-						return
-					}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
 
-	rnd.JSON(w, http.StatusOK, renderer.M{
-		"message": "Todo updated successfully",
-	})
-}
+	client, err := mongo.Connect(ctx, options.Client().ApplyURI(cfg.MongoURI()))
+	checkErr(err)
 
-func fetchTodos(w http.ResponseWriter, r *http.Request) {
-	// create a slice of todoModel as there could be a number of tasks within the database
-	todos := []todoModel{}
-	if err := db.C(collectionName).Find(bson.M{}).All(&todos); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "Failed to fetch todo",
-			"error": err,
-		})
-		return
-	}
-	// Create a todoList of type todo as it will be used to communicate with the frontend as it is in JSON format. 
-	todoList := []todo{}
-	for _, t := range todos {
-		todoList = append(todoList, todo{
-			ID: 			t.ID.Hex(),
-			Title: 		t.Title,
-			Completed:t.Completed,
-			CreatedAt:t.CreatedAt,
-		})
-	}
+	todoRepo := db.NewTodoRepository(client.Database(cfg.DBName).Collection(cfg.CollectionName))
+	userRepo := db.NewUserRepository(client.Database(cfg.DBName).Collection(cfg.UsersCollection))
+	issuer := auth.NewTokenIssuer([]byte(cfg.JWTSecret), cfg.JWTTTL)
 
-	// the data is sent as json 
-	rnd.JSON(w, http.StatusOK, renderer.M{
-		"data": todoList,
-	})
-}
+	hub := events.NewHub()
+	todoHandler := handlers.NewTodoHandler(todoRepo, hub, rnd)
+	authHandler := handlers.NewAuthHandler(userRepo, issuer, rnd)
+	streamHandler := handlers.NewStreamHandler(hub)
 
-func deleteTodo(w http.ResponseWriter, r *http.Request) {
-	// the ID field will be used to find that particular record within the database and delete it
-	id := strings.TrimSpace(chi.URLParam(r, "id"))
+	r := chi.NewRouter()
+	r.Use(middleware.Logger)
+	r.Get("/", handlers.HomeHandler(rnd))
 
-	if !bson.IsObjectIdHex(id) {
-		rnd.JSON(w, http.StatusBadRequest, renderer.M{
-			"message": "The id is invalid",
+	r.Route("/api/v1", func(api chi.Router) {
+		api.Route("/auth", func(ar chi.Router) {
+			ar.Post("/register", authHandler.Register)
+			ar.Post("/login", authHandler.Login)
 		})
-		return
-	}
 
-	if err := db.C(collectionName).RemoveId(bson.ObjectIdHex(id)); err != nil {
-		rnd.JSON(w, http.StatusProcessing, renderer.M{
-			"message": "Failed to delete todo",
-			"error": err,
+		api.Group(func(protected chi.Router) {
+			protected.Use(handlers.AuthRequired(issuer, rnd))
+			protected.Route("/todos", func(tr chi.Router) {
+				tr.Get("/stream", streamHandler.Stream)
+				tr.Mount("/", todoHandler.Routes())
+			})
 		})
-		return
-	}
-
-	rnd.JSON(w, http.StatusOK, renderer.M{
-		"message": "Todo deleted successfully",
 	})
-}
-
-func main() {
-	stopChan := make(chan os.Signal)
-	signal.Notify(stopChan, os.Interrupt)
-
-	r := chi.NewRouter()
-	r.Use(middleware.Logger)
-	r.Get("/", homeHandler)
-
-	r.Mount("/todo", todoHandlers())
 
 	srv := &http.Server{
-		Addr: 					port,
+		Addr: 					cfg.Port,
 		Handler:				r,
 		ReadTimeout: 		60 * time.Second,
 		WriteTimeout:		60 * time.Second,
 		IdleTimeout: 		60 * time.Second,
 	}
 
+	// serveErrChan surfaces a listen failure so the main goroutine can exit
+	// with a non-zero code instead of just logging and hanging around
+	serveErrChan := make(chan error, 1)
+
 	// Creating a new goroutine for accepting requests
 	go func() {
-		log.Println("Listening on port", port)
-		if err := srv.ListenAndServe(); err != nil {
-			log.Printf("Listen: %s\n", err)
+		log.Println("Listening on port", cfg.Port)
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serveErrChan <- err
+			return
 		}
+		serveErrChan <- nil
 	}()
 
-	<- stopChan
-	log.Println("Shutting down server")
-	ctx, cancel := context.WithTimeout(context.Background(), 5 * time.Second)
-	srv.Shutdown(ctx)
-	defer cancel()
-	log.Println("Server gracefully stopped")
-}
-
-// todoHandler will map the incoming requests to appropriate function to handle that type of request
-// it is grouped together as they will all have a common path of "http://localhost:9000/todo/"
-func todoHandlers() http.Handler {
-	rg := chi.NewRouter()
-	rg.Group(func(r chi.Router) {
-		r.Get("/", fetchTodos)
-		r.Post("/", createTodo)
-		r.Put("/{id}", updateTodo)
-		r.Delete("/{id}", deleteTodo)
-	})
+	select {
+	case <-stopChan:
+		log.Println("Shutting down server")
+	case err := <-serveErrChan:
+		log.Printf("Listen: %s\n", err)
+		client.Disconnect(context.Background())
+		os.Exit(1)
+	}
 
-	return rg
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	srv.Shutdown(shutdownCtx)
+	client.Disconnect(shutdownCtx)
+	log.Println("Server gracefully stopped")
 }
 
 func checkErr(err error) {
@@ -256,4 +118,4 @@ func checkErr(err error) {
 
 
 // REFERENCES
-// 1) https://www.mongodb.com/docs/drivers/go/current/fundamentals/bson/#std-label-golang-bson
\ No newline at end of file
+// 1) https://www.mongodb.com/docs/drivers/go/current/fundamentals/bson/#std-label-golang-bson
@@ -0,0 +1,42 @@
+// Package schema holds the data models shared between the db and handlers
+// packages.
+package schema
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Priority is the allowed set of values for a Todo's Priority field.
+type Priority string
+
+const (
+	PriorityLow		Priority = "low"
+	PriorityMedium	Priority = "medium"
+	PriorityHigh		Priority = "high"
+)
+
+// IsValid reports whether p is one of the known priority levels.
+func (p Priority) IsValid() bool {
+	switch p {
+	case PriorityLow, PriorityMedium, PriorityHigh:
+		return true
+	}
+	return false
+}
+
+// Todo is the persisted representation of a todo item. The same struct is
+// used to marshal BSON for Mongo and JSON for the HTTP API.
+type Todo struct {
+	ID				primitive.ObjectID	`bson:"_id,omitempty" json:"id"`
+	OwnerID		primitive.ObjectID	`bson:"owner_id" json:"owner_id"`
+	Title			string							`bson:"title" json:"title"`
+	Note			string							`bson:"note" json:"note"`
+	Completed	bool								`bson:"completed" json:"completed"`
+	DueDate		time.Time						`bson:"due_date,omitempty" json:"due_date,omitempty"`
+	Priority	Priority						`bson:"priority" json:"priority"`
+	Tags			[]string						`bson:"tags" json:"tags"`
+	CreatedAt	time.Time						`bson:"created_at" json:"created_at"`
+	UpdatedAt	time.Time						`bson:"updated_at" json:"updated_at"`
+}
@@ -0,0 +1,16 @@
+package schema
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// User is the persisted account used to authenticate API requests. Passwords
+// are never stored in plain text, only their bcrypt hash.
+type User struct {
+	ID							primitive.ObjectID	`bson:"_id,omitempty" json:"id"`
+	Email						string							`bson:"email" json:"email"`
+	PasswordHash		string							`bson:"password_hash" json:"-"`
+	CreatedAt				time.Time						`bson:"created_at" json:"created_at"`
+}